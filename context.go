@@ -21,76 +21,259 @@ const (
 
 // Context a wrapper for context.
 type Context struct {
-	ctx    context.Context
-	cancel context.CancelFunc
-	mu     sync.Mutex
+	ctx         context.Context
+	cancel      context.CancelFunc
+	cancelCause context.CancelCauseFunc
+	mu          sync.Mutex
 
 	cond *sync.Cond
 	done bool
 
 	state State
 
-	onDone    []func()
-	onCancel  []func()
-	onTimeout []func()
+	nextHandle uint64
+	onDone     map[uint64]func()
+	onCancel   map[uint64]func()
+	onTimeout  map[uint64]func()
+
+	onCancelCause  []func(error)
+	onTimeoutCause []func(error)
+
+	children map[uint64]*Context
+}
+
+// snapshotCallbacks copies the registered callbacks out of a handle-keyed map so they can be
+// invoked without holding cw.mu.
+func snapshotCallbacks(m map[uint64]func()) []func() {
+	callbacks := make([]func(), 0, len(m))
+	for _, f := range m {
+		callbacks = append(callbacks, f)
+	}
+	return callbacks
+}
+
+// copyCallbacks duplicates a handle-keyed callback map so a clone does not share registrations
+// (and stop functions) with its source.
+func copyCallbacks(m map[uint64]func()) map[uint64]func() {
+	if m == nil {
+		return nil
+	}
+	out := make(map[uint64]func(), len(m))
+	for handle, f := range m {
+		out[handle] = f
+	}
+	return out
 }
 
-// startMonitoring transitions state to "Running" and monitors context completion.
+// copyChildren duplicates a handle-keyed children map so a clone does not share the tracking map
+// with its source.
+func copyChildren(m map[uint64]*Context) map[uint64]*Context {
+	if m == nil {
+		return nil
+	}
+	out := make(map[uint64]*Context, len(m))
+	for handle, c := range m {
+		out[handle] = c
+	}
+	return out
+}
+
+// startMonitoring transitions state to "Running" and registers the context's completion with the
+// shared scheduler (see scheduler.go), instead of blocking a dedicated goroutine on cw.ctx.Done().
+// It must be launched with "go", not called inline: the caller (a constructor or Reset) returns
+// with the context still in the Created state, and registration with the scheduler blocks only
+// while the chosen worker's queue is full, so the goroutine this runs on is short-lived rather
+// than the one-per-Context monitor
+// this replaced. finishMonitoring runs on a scheduler worker once the context ends.
 func (cw *Context) startMonitoring() {
 	cw.setState(Running)
+	watchDone(cw.ctx.Done(), cw.finishMonitoring)
+}
 
-	select {
-	case <-cw.ctx.Done():
-		var newState State
-		var callbacks []func()
+// finishMonitoring classifies why the context ended, runs the matching callbacks, and wakes any
+// goroutine blocked in CancelWithWait. Invoked by the scheduler on one of its worker goroutines.
+func (cw *Context) finishMonitoring() {
+	var newState State
+	var callbacks []func()
+	var causeCallbacks []func(error)
 
-		cw.mu.Lock()
-		switch err := cw.ctx.Err(); {
-		case errors.Is(err, context.Canceled):
-			newState = Canceled
-			callbacks = cw.onCancel
-		case errors.Is(err, context.DeadlineExceeded):
-			newState = Deadlined
-			callbacks = cw.onTimeout
-		}
-		cw.mu.Unlock()
+	cw.mu.Lock()
+	switch err := cw.ctx.Err(); {
+	case errors.Is(err, context.Canceled):
+		newState = Canceled
+		callbacks = snapshotCallbacks(cw.onCancel)
+		causeCallbacks = cw.onCancelCause
+	case errors.Is(err, context.DeadlineExceeded):
+		newState = Deadlined
+		callbacks = snapshotCallbacks(cw.onTimeout)
+		causeCallbacks = cw.onTimeoutCause
+	default:
+		newState = Finished
+	}
+	doneCallbacks := snapshotCallbacks(cw.onDone)
+	cw.state = newState
+	cw.mu.Unlock()
 
-		if newState != Running {
-			cw.setState(newState)
-			for _, f := range callbacks {
-				f()
-			}
-		} else {
-			cw.setState(Finished)
-			for _, f := range cw.onDone {
-				f()
-			}
-		}
+	cause := cw.Cause()
+	for _, f := range callbacks {
+		f()
+	}
+	for _, f := range causeCallbacks {
+		f(cause)
+	}
+	for _, f := range doneCallbacks {
+		f()
 	}
 
+	cw.mu.Lock()
 	cw.done = true
+	cw.mu.Unlock()
 	cw.cond.Broadcast()
 }
 
 // OnDone adds a callback that is called when the context ends.
 func (cw *Context) OnDone(f func()) {
-	if f != nil {
-		cw.onDone = append(cw.onDone, f)
-	}
+	cw.AfterDone(f)
 }
 
 // OnCancel adds a callback that is called when the context is canceled.
 func (cw *Context) OnCancel(f func()) {
-	if f != nil {
-		cw.onCancel = append(cw.onCancel, f)
-	}
+	cw.AfterCancel(f)
 }
 
 // OnTimeout adds a callback that is called when the context time expires.
 func (cw *Context) OnTimeout(f func()) {
-	if f != nil {
-		cw.onTimeout = append(cw.onTimeout, f)
+	cw.AfterTimeout(f)
+}
+
+// AfterDone registers f to run once the context terminates, for any reason, and returns a stop
+// function that removes the registration. stop reports whether it removed f before it ran; once
+// the context has already terminated, f runs immediately (in the caller's goroutine) and stop is
+// a no-op returning false. Patterned after context.AfterFunc. The state check and the
+// registration happen under one cw.mu critical section, the same lock finishMonitoring holds
+// while taking its callback snapshot, so a termination racing this call can never see the check
+// before registration and the registration after the snapshot.
+func (cw *Context) AfterDone(f func()) (stop func() bool) {
+	cw.mu.Lock()
+	if cw.state != Created && cw.state != Running {
+		cw.mu.Unlock()
+		if f != nil {
+			f()
+		}
+		return func() bool { return false }
+	}
+	if f == nil {
+		cw.mu.Unlock()
+		return func() bool { return false }
+	}
+	handle := cw.registerLocked(&cw.onDone, f)
+	cw.mu.Unlock()
+	return cw.stopFunc(&cw.onDone, handle)
+}
+
+// AfterCancel registers f to run if and when the context is canceled, and returns a stop function
+// that removes the registration. stop reports whether it removed f before it ran. If the context
+// has already been canceled, f runs immediately; if it has already terminated some other way, f
+// will never run and stop is a no-op returning false. See AfterDone for why the state check and
+// registration share a single critical section.
+func (cw *Context) AfterCancel(f func()) (stop func() bool) {
+	cw.mu.Lock()
+	switch cw.state {
+	case Canceled:
+		cw.mu.Unlock()
+		if f != nil {
+			f()
+		}
+		return func() bool { return false }
+	case Created, Running:
+		if f == nil {
+			cw.mu.Unlock()
+			return func() bool { return false }
+		}
+		handle := cw.registerLocked(&cw.onCancel, f)
+		cw.mu.Unlock()
+		return cw.stopFunc(&cw.onCancel, handle)
+	default:
+		cw.mu.Unlock()
+		return func() bool { return false }
+	}
+}
+
+// AfterTimeout registers f to run if and when the context's deadline expires, and returns a stop
+// function that removes the registration. stop reports whether it removed f before it ran. If the
+// deadline has already expired, f runs immediately; if the context has already terminated some
+// other way, f will never run and stop is a no-op returning false. See AfterDone for why the
+// state check and registration share a single critical section.
+func (cw *Context) AfterTimeout(f func()) (stop func() bool) {
+	cw.mu.Lock()
+	switch cw.state {
+	case Deadlined:
+		cw.mu.Unlock()
+		if f != nil {
+			f()
+		}
+		return func() bool { return false }
+	case Created, Running:
+		if f == nil {
+			cw.mu.Unlock()
+			return func() bool { return false }
+		}
+		handle := cw.registerLocked(&cw.onTimeout, f)
+		cw.mu.Unlock()
+		return cw.stopFunc(&cw.onTimeout, handle)
+	default:
+		cw.mu.Unlock()
+		return func() bool { return false }
+	}
+}
+
+// registerLocked inserts f into *m under a fresh handle and returns that handle. Callers must
+// hold cw.mu.
+func (cw *Context) registerLocked(m *map[uint64]func(), f func()) uint64 {
+	if *m == nil {
+		*m = make(map[uint64]func())
+	}
+	cw.nextHandle++
+	handle := cw.nextHandle
+	(*m)[handle] = f
+	return handle
+}
+
+// stopFunc returns a stop function that removes handle from *m, reporting whether it was still
+// present and the context had not yet terminated.
+func (cw *Context) stopFunc(m *map[uint64]func(), handle uint64) func() bool {
+	return func() bool {
+		cw.mu.Lock()
+		defer cw.mu.Unlock()
+		if cw.state != Created && cw.state != Running {
+			return false
+		}
+		if _, ok := (*m)[handle]; !ok {
+			return false
+		}
+		delete(*m, handle)
+		return true
+	}
+}
+
+// OnCancelCause adds a callback that is called with the cancellation cause when the context is canceled.
+func (cw *Context) OnCancelCause(f func(error)) {
+	if f == nil {
+		return
+	}
+	cw.mu.Lock()
+	cw.onCancelCause = append(cw.onCancelCause, f)
+	cw.mu.Unlock()
+}
+
+// OnTimeoutCause adds a callback that is called with the cancellation cause when the context time expires.
+func (cw *Context) OnTimeoutCause(f func(error)) {
+	if f == nil {
+		return
 	}
+	cw.mu.Lock()
+	cw.onTimeoutCause = append(cw.onTimeoutCause, f)
+	cw.mu.Unlock()
 }
 
 // Done returns the context's done channel.
@@ -100,6 +283,8 @@ func (cw *Context) Done() <-chan struct{} {
 
 // IsDone returns true if the context has finished.
 func (cw *Context) IsDone() bool {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
 	return cw.state != Created && cw.state != Running
 }
 
@@ -123,6 +308,28 @@ func (cw *Context) Err() error {
 	return cw.ctx.Err()
 }
 
+// CancelWithCause causes the context to be canceled, recording err as the cause.
+// If the context was not created with a cause-aware constructor, this behaves like Cancel.
+func (cw *Context) CancelWithCause(err error) {
+	cw.mu.Lock()
+	cancelCause := cw.cancelCause
+	cw.mu.Unlock()
+
+	if cancelCause != nil {
+		cancelCause(err)
+		return
+	}
+	cw.cancel()
+}
+
+// Cause returns the cause of the context's termination, falling back to Err() when none is set.
+func (cw *Context) Cause() error {
+	if cause := context.Cause(cw.ctx); cause != nil {
+		return cause
+	}
+	return cw.ctx.Err()
+}
+
 // Value returns a value stored in the context.
 func (cw *Context) Value(key interface{}) interface{} {
 	return cw.ctx.Value(key)
@@ -142,36 +349,77 @@ func (cw *Context) setState(state State) {
 	cw.state = state
 }
 
-// Reset resets the context to the initial "Created" state for reuse.
+// Reset resets the context to the initial "Created" state for reuse. If the previous underlying
+// context has not yet terminated, Reset cancels it and waits for its completion to be processed
+// before installing the new one, so the old and new watches can never race on cw's state.
 func (cw *Context) Reset(parent context.Context) {
 	cw.mu.Lock()
-	defer cw.mu.Unlock()
+	if !cw.done {
+		cancel := cw.cancel
+		cw.mu.Unlock()
+		cancel()
+		cw.mu.Lock()
+		for !cw.done {
+			cw.cond.Wait()
+		}
+	}
+
 	ctx, cancel := context.WithCancel(parent)
 	cw.ctx = ctx
 	cw.cancel = cancel
+	cw.cancelCause = nil
 	cw.state = Created
 	cw.onDone = nil
 	cw.onCancel = nil
 	cw.onTimeout = nil
+	cw.onCancelCause = nil
+	cw.onTimeoutCause = nil
+	cw.children = nil
 	cw.done = false
+	cw.mu.Unlock()
+
 	go cw.startMonitoring()
 }
 
 // WithValue adds a key-value pair to the context.
 func (cw *Context) WithValue(key, value interface{}) {
+	cw.mu.Lock()
 	cw.ctx = context.WithValue(cw.ctx, key, value)
+	cw.mu.Unlock()
 }
 
-// Clone creates a copy of the current context with the same state and callbacks.
+// Clone creates a copy of the current context with the same state and callbacks, inheriting cw's
+// values but not its cancellation: the clone gets its own derived context.Context (so it only
+// reacts to its own Cancel/CancelWithCause, not cw's), plus its own mutex, condition variable,
+// and scheduler watch. Without a context of its own, the clone's copied callbacks would share
+// cw's Done() channel and fire a second time whenever cw's own watcher fired them.
 func (cw *Context) Clone() *Context {
+	cw.mu.Lock()
+	values := cw.ctx
+	hasCause := cw.cancelCause != nil
 	newCtx := &Context{
-		ctx:       cw.ctx,
-		cancel:    cw.cancel,
-		state:     cw.state,
-		onDone:    append([]func(){}, cw.onDone...),
-		onCancel:  append([]func(){}, cw.onCancel...),
-		onTimeout: append([]func(){}, cw.onTimeout...),
+		state:          cw.state,
+		nextHandle:     cw.nextHandle,
+		onDone:         copyCallbacks(cw.onDone),
+		onCancel:       copyCallbacks(cw.onCancel),
+		onTimeout:      copyCallbacks(cw.onTimeout),
+		onCancelCause:  append([]func(error){}, cw.onCancelCause...),
+		onTimeoutCause: append([]func(error){}, cw.onTimeoutCause...),
+		children:       copyChildren(cw.children),
 	}
+	cw.mu.Unlock()
+
+	valuesOnly := context.WithoutCancel(values)
+	if hasCause {
+		ctx, cancel := context.WithCancelCause(valuesOnly)
+		newCtx.ctx, newCtx.cancel, newCtx.cancelCause = ctx, func() { cancel(nil) }, cancel
+	} else {
+		ctx, cancel := context.WithCancel(valuesOnly)
+		newCtx.ctx, newCtx.cancel = ctx, cancel
+	}
+
+	newCtx.cond = sync.NewCond(&newCtx.mu)
+	go newCtx.startMonitoring()
 	return newCtx
 }
 
@@ -194,13 +442,14 @@ func (cw *Context) GetContext() context.Context {
 	return cw.ctx
 }
 
-// NewContextWithCancel creates a new Context with the ability to cancel.
-func NewContextWithCancel(parent context.Context) *Context {
-	ctx, cancel := context.WithCancel(parent)
+// newWrappedContext builds a Context around an already-derived context.Context and registers its
+// completion with the shared scheduler. Shared by every constructor below.
+func newWrappedContext(ctx context.Context, cancel context.CancelFunc, cancelCause context.CancelCauseFunc) *Context {
 	cw := &Context{
-		ctx:    ctx,
-		cancel: cancel,
-		state:  Created,
+		ctx:         ctx,
+		cancel:      cancel,
+		cancelCause: cancelCause,
+		state:       Created,
 	}
 
 	cw.cond = sync.NewCond(&cw.mu)
@@ -209,17 +458,107 @@ func NewContextWithCancel(parent context.Context) *Context {
 	return cw
 }
 
+// NewContextWithCancel creates a new Context with the ability to cancel.
+func NewContextWithCancel(parent context.Context) *Context {
+	ctx, cancel := context.WithCancel(parent)
+	return newWrappedContext(ctx, cancel, nil)
+}
+
 // NewContextWithTimeout creates a new Context with a timeout.
 func NewContextWithTimeout(parent context.Context, timeout time.Duration) *Context {
 	ctx, cancel := context.WithTimeout(parent, timeout)
-	cw := &Context{
-		ctx:    ctx,
-		cancel: cancel,
-		state:  Created,
+	return newWrappedContext(ctx, cancel, nil)
+}
+
+// NewContextWithCancelCause creates a new Context whose cancellation can carry a cause,
+// retrievable later via Cause().
+func NewContextWithCancelCause(parent context.Context) *Context {
+	ctx, cancel := context.WithCancelCause(parent)
+	return newWrappedContext(ctx, func() { cancel(nil) }, cancel)
+}
+
+// NewContextWithDeadlineCause creates a new Context with a deadline whose cancellation can
+// carry a cause, retrievable later via Cause(). Unlike context.WithDeadlineCause, whose cause is
+// fixed at construction, the cause here stays settable at any time via CancelWithCause: the
+// deadline is enforced by deriving a plain context.WithDeadline from a context.WithCancelCause,
+// so an expiry ends only the derived deadline context, whose cause is then
+// context.DeadlineExceeded.
+func NewContextWithDeadlineCause(parent context.Context, d time.Time) *Context {
+	causeCtx, cancel := context.WithCancelCause(parent)
+	ctx, deadlineCancel := context.WithDeadline(causeCtx, d)
+	return newWrappedContext(ctx, func() { deadlineCancel(); cancel(nil) }, cancel)
+}
+
+// Derive creates a child Context wired to parent: canceling (or timing out) parent cancels the
+// child too, since the child's underlying context.Context is derived from parent's. The child
+// unregisters itself from parent once it terminates, so a long-lived parent does not accumulate
+// finished children.
+func Derive(parent *Context) *Context {
+	child := NewContextWithCancel(parent.GetContext())
+	parent.attachChild(child)
+	return child
+}
+
+// DeriveWithTimeout creates a child Context like Derive, additionally canceling the child after
+// timeout elapses even if parent has not terminated.
+func DeriveWithTimeout(parent *Context, timeout time.Duration) *Context {
+	child := NewContextWithTimeout(parent.GetContext(), timeout)
+	parent.attachChild(child)
+	return child
+}
+
+// DeriveWithDeadline creates a child Context like Derive, additionally canceling the child at d
+// even if parent has not terminated.
+func DeriveWithDeadline(parent *Context, d time.Time) *Context {
+	ctx, cancel := context.WithDeadline(parent.GetContext(), d)
+	child := newWrappedContext(ctx, cancel, nil)
+	parent.attachChild(child)
+	return child
+}
+
+// attachChild registers child under a fresh handle so Children() can report it, and arranges for
+// it to be removed once the child terminates.
+func (cw *Context) attachChild(child *Context) {
+	cw.mu.Lock()
+	if cw.children == nil {
+		cw.children = make(map[uint64]*Context)
 	}
+	cw.nextHandle++
+	handle := cw.nextHandle
+	cw.children[handle] = child
+	cw.mu.Unlock()
 
-	cw.cond = sync.NewCond(&cw.mu)
+	child.AfterDone(func() { cw.removeChild(handle) })
+}
 
-	go cw.startMonitoring()
-	return cw
+// removeChild drops a terminated child from the tracking map.
+func (cw *Context) removeChild(handle uint64) {
+	cw.mu.Lock()
+	delete(cw.children, handle)
+	cw.mu.Unlock()
+}
+
+// Children returns the Context's currently live (not yet terminated) derived children.
+func (cw *Context) Children() []*Context {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	children := make([]*Context, 0, len(cw.children))
+	for _, c := range cw.children {
+		children = append(children, c)
+	}
+	return children
+}
+
+// WithoutCancel creates a new Context that inherits parent's values but starts fresh in the
+// Created state and is never canceled or deadlined by parent: it is deliberately not attached
+// via attachChild, so parent cancellation does not cascade to it. It has no deadline; only its
+// own Cancel (or CancelWithWait) will terminate it.
+func WithoutCancel(parent *Context) *Context {
+	return NewContextWithCancel(context.WithoutCancel(parent.GetContext()))
+}
+
+// Detach returns a value-only child of cw: it sees cw's values but is excluded from cw's
+// cascade cancellation, making it suitable for background work that must outlive cw.
+func (cw *Context) Detach() *Context {
+	return WithoutCancel(cw)
 }
@@ -2,6 +2,8 @@ package eactx
 
 import (
 	"context"
+	"errors"
+	"runtime"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -86,6 +88,218 @@ func TestContextWithValue(t *testing.T) {
 	}
 }
 
+func TestDeriveCascadesCancellation(t *testing.T) {
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	parent := context.Background()
+	root := NewContextWithCancel(parent)
+
+	const depth = 50
+	chain := make([]*Context, depth)
+	node := root
+	for i := 0; i < depth; i++ {
+		node = Derive(node)
+		chain[i] = node
+	}
+
+	root.CancelWithWait()
+
+	for i, c := range chain {
+		select {
+		case <-c.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("child %d never observed cancellation", i)
+		}
+
+		// c.Done() closing only means the underlying context.Context has propagated the
+		// cancellation; c's own state transition still runs asynchronously on a scheduler
+		// worker, so give it a moment to catch up before asserting.
+		deadline := time.Now().Add(time.Second)
+		for c.State() != Canceled && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if c.State() != Canceled {
+			t.Errorf("child %d: expected state %v, got %v", i, Canceled, c.State())
+		}
+	}
+
+	runtime.GC()
+	after := runtime.NumGoroutine()
+	if after > before+2 {
+		t.Errorf("expected goroutine count to stay roughly stable after cascading cancellation, went from %d to %d", before, after)
+	}
+}
+
+func TestDeriveUnregistersOnTermination(t *testing.T) {
+	parent := context.Background()
+	root := NewContextWithCancel(parent)
+	child := Derive(root)
+
+	if len(root.Children()) != 1 {
+		t.Fatalf("expected 1 child before cancellation, got %d", len(root.Children()))
+	}
+
+	child.CancelWithWait()
+
+	deadline := time.Now().Add(time.Second)
+	for len(root.Children()) != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if len(root.Children()) != 0 {
+		t.Errorf("expected child to unregister after terminating, got %d children", len(root.Children()))
+	}
+}
+
+func TestDetachSurvivesParentCancellation(t *testing.T) {
+	parent := context.Background()
+	root := NewContextWithCancel(parent)
+	root.WithValue("key", "value")
+
+	detached := root.Detach()
+
+	root.CancelWithWait()
+
+	if detached.IsDone() {
+		t.Error("expected detached context to survive parent cancellation")
+	}
+	if detached.Value("key") != "value" {
+		t.Errorf("expected detached context to inherit parent values, got %v", detached.Value("key"))
+	}
+
+	detached.CancelWithWait()
+	if detached.State() != Canceled {
+		t.Errorf("expected detached context to still be independently cancelable, got %v", detached.State())
+	}
+}
+
+func TestContextResetStressNoGoroutineLeak(t *testing.T) {
+	parent := context.Background()
+	ctx := NewContextWithCancel(parent)
+	ctx.CancelWithWait()
+
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	const cycles = 200
+	for i := 0; i < cycles; i++ {
+		ctx.Reset(parent)
+		var called int32
+		ctx.OnCancel(func() { atomic.AddInt32(&called, 1) })
+		ctx.CancelWithWait()
+		if atomic.LoadInt32(&called) != 1 {
+			t.Fatalf("cycle %d: expected OnCancel to run once", i)
+		}
+	}
+
+	runtime.GC()
+	after := runtime.NumGoroutine()
+	if after > before+2 {
+		t.Errorf("expected goroutine count to stay roughly stable, went from %d to %d", before, after)
+	}
+}
+
+func TestContextAfterCancelStop(t *testing.T) {
+	parent := context.Background()
+	ctx := NewContextWithCancel(parent)
+
+	var called int32
+	stop := ctx.AfterCancel(func() { atomic.AddInt32(&called, 1) })
+
+	if !stop() {
+		t.Error("expected stop to report true before cancellation")
+	}
+
+	ctx.CancelWithWait()
+
+	if atomic.LoadInt32(&called) != 0 {
+		t.Error("expected stopped callback not to run")
+	}
+	if stop() {
+		t.Error("expected a second stop call to report false")
+	}
+}
+
+func TestContextAfterCancelAlreadyTerminated(t *testing.T) {
+	parent := context.Background()
+	ctx := NewContextWithCancel(parent)
+	ctx.CancelWithWait()
+
+	var called int32
+	stop := ctx.AfterCancel(func() { atomic.AddInt32(&called, 1) })
+
+	if atomic.LoadInt32(&called) != 1 {
+		t.Error("expected callback to run immediately on an already-canceled context")
+	}
+	if stop() {
+		t.Error("expected stop on an already-fired callback to report false")
+	}
+}
+
+func TestContextCancelWithCause(t *testing.T) {
+	parent := context.Background()
+	ctx := NewContextWithCancelCause(parent)
+
+	var gotCause error
+	ctx.OnCancelCause(func(err error) { gotCause = err })
+
+	wantErr := errors.New("shutting down")
+	ctx.CancelWithCause(wantErr)
+	ctx.CancelWithWait()
+
+	if !errors.Is(ctx.Cause(), wantErr) {
+		t.Errorf("expected Cause to be %v, got %v", wantErr, ctx.Cause())
+	}
+	if !errors.Is(gotCause, wantErr) {
+		t.Errorf("expected OnCancelCause callback to receive %v, got %v", wantErr, gotCause)
+	}
+}
+
+func TestContextCauseFallsBackToErr(t *testing.T) {
+	parent := context.Background()
+	ctx := NewContextWithCancel(parent)
+
+	ctx.CancelWithWait()
+
+	if !errors.Is(ctx.Cause(), context.Canceled) {
+		t.Errorf("expected Cause to fall back to %v, got %v", context.Canceled, ctx.Cause())
+	}
+}
+
+func TestNewContextWithDeadlineCauseExpiresNaturally(t *testing.T) {
+	parent := context.Background()
+	timeout := 50 * time.Millisecond
+	ctx := NewContextWithDeadlineCause(parent, time.Now().Add(timeout))
+
+	time.Sleep(2 * timeout)
+
+	if ctx.State() != Deadlined {
+		t.Errorf("expected state to be %v after deadline, got %v", Deadlined, ctx.State())
+	}
+	if !errors.Is(ctx.Cause(), context.DeadlineExceeded) {
+		t.Errorf("expected Cause to fall back to %v, got %v", context.DeadlineExceeded, ctx.Cause())
+	}
+}
+
+func TestNewContextWithDeadlineCauseCancelBeforeDeadline(t *testing.T) {
+	parent := context.Background()
+	ctx := NewContextWithDeadlineCause(parent, time.Now().Add(time.Hour))
+
+	var gotCause error
+	ctx.OnCancelCause(func(err error) { gotCause = err })
+
+	wantErr := errors.New("shutting down")
+	ctx.CancelWithCause(wantErr)
+	ctx.CancelWithWait()
+
+	if !errors.Is(ctx.Cause(), wantErr) {
+		t.Errorf("expected Cause to be %v, got %v", wantErr, ctx.Cause())
+	}
+	if !errors.Is(gotCause, wantErr) {
+		t.Errorf("expected OnCancelCause callback to receive %v, got %v", wantErr, gotCause)
+	}
+}
+
 func TestContextClone(t *testing.T) {
 	parent := context.Background()
 	ctx := NewContextWithCancel(parent)
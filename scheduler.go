@@ -0,0 +1,156 @@
+package eactx
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// schedulerAddQueueSize bounds how many pending watch registrations a worker can buffer before
+// watch blocks; a worker receives them one at a time as case 0 of its reflect.Select.
+const schedulerAddQueueSize = 256
+
+// watchRequest is a single "tell me when done closes" registration handed to a schedulerWorker.
+type watchRequest struct {
+	done <-chan struct{}
+	fire func()
+}
+
+// schedulerWorker multiplexes an arbitrary number of Done() channels with a single
+// reflect.Select loop, so watching N contexts costs one goroutine instead of N.
+type schedulerWorker struct {
+	add  chan watchRequest
+	quit chan struct{}
+}
+
+func newSchedulerWorker() *schedulerWorker {
+	w := &schedulerWorker{add: make(chan watchRequest, schedulerAddQueueSize), quit: make(chan struct{})}
+	go w.run()
+	return w
+}
+
+// run services w.add until w is stopped and every queued or registered watch has fired,
+// rebuilding its reflect.Select case list as watches are added and as watched channels fire.
+// Case 0 is always w.add; case 1 is w.quit until stop is called, then a nil channel.
+func (w *schedulerWorker) run() {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w.add)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w.quit)},
+	}
+	fires := []func(){nil, nil}
+	stopping := false
+
+	for {
+		chosen, recv, ok := reflect.Select(cases)
+
+		switch chosen {
+		case 0:
+			if !ok {
+				return
+			}
+			req := recv.Interface().(watchRequest)
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(req.done)})
+			fires = append(fires, req.fire)
+		case 1:
+			// Disable the quit case instead of removing it, so the indices of every watch
+			// case stay put; a nil channel blocks forever and so is never selected again.
+			stopping = true
+			cases[1] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf((chan struct{})(nil))}
+			if len(cases) == 2 && len(w.add) == 0 {
+				return
+			}
+		default:
+			fire := fires[chosen]
+			last := len(cases) - 1
+			cases[chosen] = cases[last]
+			fires[chosen] = fires[last]
+			cases = cases[:last]
+			fires = fires[:last]
+
+			if fire != nil {
+				fire()
+			}
+			if stopping && len(cases) == 2 && len(w.add) == 0 {
+				return
+			}
+		}
+	}
+}
+
+func (w *schedulerWorker) watch(done <-chan struct{}, fire func()) {
+	w.add <- watchRequest{done: done, fire: fire}
+}
+
+// stop tells w to exit once its currently registered watches have all fired; watches already in
+// flight still run to completion, only no new ones can be handed to w after the caller stops
+// referencing it.
+func (w *schedulerWorker) stop() {
+	close(w.quit)
+}
+
+// scheduler is a fixed-size pool of schedulerWorkers that Done() watches are load-balanced
+// across, so a program creating many short-lived Contexts pays for a handful of goroutines
+// rather than one per Context.
+type scheduler struct {
+	workers []*schedulerWorker
+	rr      uint64
+}
+
+func newScheduler(workers int) *scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	s := &scheduler{workers: make([]*schedulerWorker, workers)}
+	for i := range s.workers {
+		s.workers[i] = newSchedulerWorker()
+	}
+	return s
+}
+
+// watch registers fire to run once done closes, picking a worker round-robin.
+func (s *scheduler) watch(done <-chan struct{}, fire func()) {
+	idx := int(atomic.AddUint64(&s.rr, 1) % uint64(len(s.workers)))
+	s.workers[idx].watch(done, fire)
+}
+
+// stop tells every worker in s to exit once its in-flight watches finish firing.
+func (s *scheduler) stop() {
+	for _, w := range s.workers {
+		w.stop()
+	}
+}
+
+var (
+	schedulerMu      sync.Mutex
+	defaultScheduler = newScheduler(defaultSchedulerWorkers())
+)
+
+func defaultSchedulerWorkers() int {
+	if n := runtime.GOMAXPROCS(0); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// SetSchedulerWorkers resizes the shared scheduler pool used to multiplex Context completions.
+// Watches already registered with the previous pool keep running to completion on it, and its
+// workers exit as soon as their own watch sets drain; only Contexts created or Reset after this
+// call are balanced across the new pool. Safe to call concurrently with Context creation.
+func SetSchedulerWorkers(n int) {
+	schedulerMu.Lock()
+	old := defaultScheduler
+	defaultScheduler = newScheduler(n)
+	schedulerMu.Unlock()
+
+	old.stop()
+}
+
+// watchDone hands ctx's Done() channel to the shared scheduler, invoking fire once it closes.
+// The hand-off happens under schedulerMu, so it can never reach a pool that SetSchedulerWorkers
+// has already stopped.
+func watchDone(done <-chan struct{}, fire func()) {
+	schedulerMu.Lock()
+	defer schedulerMu.Unlock()
+	defaultScheduler.watch(done, fire)
+}
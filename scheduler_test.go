@@ -0,0 +1,83 @@
+package eactx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerWatchFires(t *testing.T) {
+	s := newScheduler(2)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	fired := make(chan struct{})
+	s.watch(ctx.Done(), func() { close(fired) })
+
+	cancel()
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("expected watch callback to fire after cancellation")
+	}
+}
+
+func TestSetSchedulerWorkers(t *testing.T) {
+	SetSchedulerWorkers(4)
+	defer SetSchedulerWorkers(defaultSchedulerWorkers())
+
+	parent := context.Background()
+	ctx := NewContextWithCancel(parent)
+	ctx.CancelWithWait()
+
+	if ctx.State() != Canceled {
+		t.Errorf("expected state to be %v after cancel, got %v", Canceled, ctx.State())
+	}
+}
+
+// benchmarkNaiveGoroutinePerContext measures the old approach this package used to take: one
+// dedicated goroutine per context, blocked on <-ctx.Done().
+func benchmarkNaiveGoroutinePerContext(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		parent := context.Background()
+		cancels := make([]context.CancelFunc, n)
+		var wg sync.WaitGroup
+		wg.Add(n)
+
+		for j := 0; j < n; j++ {
+			ctx, cancel := context.WithCancel(parent)
+			cancels[j] = cancel
+			go func(ctx context.Context) {
+				defer wg.Done()
+				<-ctx.Done()
+			}(ctx)
+		}
+
+		for j := 0; j < n; j++ {
+			cancels[j]()
+		}
+		wg.Wait()
+	}
+}
+
+// benchmarkPooledScheduler measures eactx.Context's current approach: completions are
+// multiplexed across a small scheduler worker pool instead of one goroutine each.
+func benchmarkPooledScheduler(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		parent := context.Background()
+		ctxs := make([]*Context, n)
+
+		for j := 0; j < n; j++ {
+			ctxs[j] = NewContextWithCancel(parent)
+		}
+		for j := 0; j < n; j++ {
+			ctxs[j].CancelWithWait()
+		}
+	}
+}
+
+func BenchmarkNaiveGoroutinePerContext10k(b *testing.B)  { benchmarkNaiveGoroutinePerContext(b, 10_000) }
+func BenchmarkNaiveGoroutinePerContext100k(b *testing.B) { benchmarkNaiveGoroutinePerContext(b, 100_000) }
+func BenchmarkPooledScheduler10k(b *testing.B)           { benchmarkPooledScheduler(b, 10_000) }
+func BenchmarkPooledScheduler100k(b *testing.B)          { benchmarkPooledScheduler(b, 100_000) }